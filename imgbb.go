@@ -6,9 +6,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"math/rand"
 	"mime/multipart"
 	"net/http"
 	"strconv"
+	"sync"
+	"time"
 )
 
 const (
@@ -20,31 +23,91 @@ const (
 
 // Image is a struct with image data to upload.
 type Image struct {
-	name   string
-	size   int
-	ttl    uint64
-	source string
-	file   []byte
+	name     string
+	size     int
+	ttl      uint64
+	source   string
+	file     []byte
+	progress ProgressFunc
+}
+
+// ProgressFunc is called as file bytes are written to the upload request, and once
+// more with bytesWritten == totalBytes after the last byte is written. For an Image
+// built from a URL/base64 source it is called once, with the source's length.
+type ProgressFunc func(bytesWritten, totalBytes int64)
+
+// ImageOption configures an Image at construction time.
+type ImageOption func(*Image)
+
+// WithProgress attaches a ProgressFunc that is invoked as the image is uploaded.
+func WithProgress(fn ProgressFunc) ImageOption {
+	return func(img *Image) {
+		img.progress = fn
+	}
 }
 
 // NewImage creates a new Image.
-func NewImage(name string, ttl uint64, source string) (*Image, error) {
-	return &Image{
+func NewImage(name string, ttl uint64, source string, opts ...ImageOption) (*Image, error) {
+	img := &Image{
 		name:   name,
 		size:   len(source),
 		ttl:    ttl,
 		source: source,
-	}, nil
+	}
+
+	for _, opt := range opts {
+		opt(img)
+	}
+
+	return img, nil
+}
+
+// Name returns the image's upload name.
+func (img Image) Name() string {
+	return img.name
+}
+
+// TTL returns the image's expiration in seconds, or 0 if it does not expire.
+func (img Image) TTL() uint64 {
+	return img.ttl
+}
+
+// Source returns the image's URL/base64 source, if it was built from one.
+func (img Image) Source() string {
+	return img.source
+}
+
+// File returns the image's raw file bytes, if it was built from one.
+func (img Image) File() []byte {
+	return img.file
+}
+
+// Size returns the size, in bytes, of the image's source or file.
+func (img Image) Size() int {
+	return img.size
+}
+
+// Progress returns the ProgressFunc attached to the image, if any. Transformers
+// that rebuild an Image should carry this forward with WithProgress, or progress
+// callbacks will silently stop firing once a transformer runs.
+func (img Image) Progress() ProgressFunc {
+	return img.progress
 }
 
 // NewImageFromFile creates a new Image from file.
-func NewImageFromFile(name string, ttl uint64, file []byte) (*Image, error) {
-	return &Image{
+func NewImageFromFile(name string, ttl uint64, file []byte, opts ...ImageOption) (*Image, error) {
+	img := &Image{
 		name: name,
 		size: len(file),
 		ttl:  ttl,
 		file: file,
-	}, nil
+	}
+
+	for _, opt := range opts {
+		opt(img)
+	}
+
+	return img, nil
 }
 
 // Error is an upload error response.
@@ -99,18 +162,115 @@ type Info struct {
 	URL       string `json:"url"`
 }
 
+// Transformer transforms an Image before it is uploaded, e.g. to resize, re-encode,
+// or compress it.
+type Transformer interface {
+	Transform(ctx context.Context, in Image) (Image, error)
+}
+
 // Client is an imgbb api client.
 type Client struct {
 	client *http.Client
 
-	key string
+	key          string
+	transformers []Transformer
+	retry        *RetryPolicy
+
+	endpoint string
+	host     string
+	origin   string
+	referer  string
+}
+
+// ClientOption configures a Client at construction time.
+type ClientOption func(*Client)
+
+// WithTransformers sets a chain of Transformers applied, in order, to every Image
+// before it is uploaded.
+func WithTransformers(transformers ...Transformer) ClientOption {
+	return func(c *Client) {
+		c.transformers = transformers
+	}
+}
+
+// WithEndpoint points the client at a different Chevereto-compatible upload
+// endpoint (e.g. Freeimage.host), which accepts the same multipart form contract
+// as ImgBB.
+func WithEndpoint(endpoint, host, origin, referer string) ClientOption {
+	return func(c *Client) {
+		c.endpoint = endpoint
+		c.host = host
+		c.origin = origin
+		c.referer = referer
+	}
+}
+
+// RetryPolicy configures automatic retries for Client.Upload.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first. Defaults
+	// to 3.
+	MaxAttempts int
+	// BaseDelay is the starting backoff delay. Defaults to 500ms.
+	BaseDelay time.Duration
+	// MaxDelay caps the backoff delay. Defaults to 30s.
+	MaxDelay time.Duration
+	// Retryable decides whether a failed attempt should be retried. resp is nil
+	// when err came from the transport rather than the server. Defaults to
+	// retrying network errors, 429, and 5xx responses.
+	Retryable func(err error, resp *http.Response) bool
+	// OnRetry, if set, is called before each retry's backoff sleep.
+	OnRetry func(attempt int, err error, wait time.Duration)
+}
+
+// WithRetry makes the client retry failed uploads per policy, using full-jitter
+// exponential backoff and honouring any Retry-After header on the response.
+func WithRetry(policy RetryPolicy) ClientOption {
+	if policy.MaxAttempts <= 0 {
+		policy.MaxAttempts = 3
+	}
+
+	if policy.BaseDelay <= 0 {
+		policy.BaseDelay = 500 * time.Millisecond
+	}
+
+	if policy.MaxDelay <= 0 {
+		policy.MaxDelay = 30 * time.Second
+	}
+
+	if policy.Retryable == nil {
+		policy.Retryable = defaultRetryable
+	}
+
+	return func(c *Client) {
+		c.retry = &policy
+	}
+}
+
+func defaultRetryable(_ error, resp *http.Response) bool {
+	// resp is nil only when the request never got a response at all (DNS failure,
+	// connection refused, timeout, ...) — always worth retrying. Once we have a
+	// status code, only 429 and 5xx are retryable; a 4xx like bad key/bad image
+	// will keep failing and shouldn't burn the caller's retry budget.
+	if resp == nil {
+		return true
+	}
+
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
 }
 
 // NewClient create a new ImgBB api client.
-func NewClient(client *http.Client, key string) *Client {
+func NewClient(client *http.Client, key string, opts ...ClientOption) *Client {
 	imgBB := &Client{
-		client: client,
-		key:    key,
+		client:   client,
+		key:      key,
+		endpoint: endpoint,
+		host:     host,
+		origin:   origin,
+		referer:  referer,
+	}
+
+	for _, opt := range opts {
+		opt(imgBB)
 	}
 
 	return imgBB
@@ -118,18 +278,239 @@ func NewClient(client *http.Client, key string) *Client {
 
 // Upload is a function to upload image to ImgBB.
 func (i *Client) Upload(ctx context.Context, img *Image) (Response, error) {
-	req, err := i.prepareRequest(ctx, img)
+	img, err := i.applyTransformers(ctx, img)
 	if err != nil {
 		return Response{}, err
 	}
 
+	if i.retry == nil {
+		result, _, err := i.doUpload(ctx, img)
+
+		return result, err
+	}
+
+	return i.uploadWithRetry(ctx, img)
+}
+
+func (i *Client) doUpload(ctx context.Context, img *Image) (Response, *http.Response, error) {
+	req, err := i.prepareRequest(ctx, img)
+	if err != nil {
+		return Response{}, nil, err
+	}
+
 	resp, err := i.client.Do(req)
 	if err != nil {
-		return Response{}, fmt.Errorf("%w", err)
+		return Response{}, nil, fmt.Errorf("%w", err)
 	}
 	defer resp.Body.Close()
 
-	return i.respParse(resp)
+	result, err := i.respParse(resp)
+
+	return result, resp, err
+}
+
+func (i *Client) uploadWithRetry(ctx context.Context, img *Image) (Response, error) {
+	policy := i.retry
+
+	var lastErr error
+
+	for attempt := 0; attempt < policy.MaxAttempts; attempt++ {
+		result, resp, err := i.doUpload(ctx, img)
+		if err == nil {
+			return result, nil
+		}
+
+		lastErr = err
+
+		if attempt == policy.MaxAttempts-1 || !policy.Retryable(err, resp) {
+			return Response{}, err
+		}
+
+		wait := fullJitterBackoff(policy.BaseDelay, policy.MaxDelay, attempt)
+		if retryAfter, ok := retryAfterDelay(resp); ok && retryAfter > wait {
+			wait = retryAfter
+		}
+
+		if policy.OnRetry != nil {
+			policy.OnRetry(attempt+1, err, wait)
+		}
+
+		timer := time.NewTimer(wait)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return Response{}, ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	return Response{}, lastErr
+}
+
+// fullJitterBackoff returns a random delay in [0, min(max, base*2^attempt)], per
+// the "full jitter" strategy.
+func fullJitterBackoff(base, maxDelay time.Duration, attempt int) time.Duration {
+	ceiling := base << attempt
+	if ceiling <= 0 || ceiling > maxDelay {
+		ceiling = maxDelay
+	}
+
+	return time.Duration(rand.Int63n(int64(ceiling) + 1))
+}
+
+// retryAfterDelay parses a Retry-After header, in either the delay-seconds or
+// HTTP-date form.
+func retryAfterDelay(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if when, err := http.ParseTime(value); err == nil {
+		return time.Until(when), true
+	}
+
+	return 0, false
+}
+
+func (i *Client) applyTransformers(ctx context.Context, img *Image) (*Image, error) {
+	if len(i.transformers) == 0 {
+		return img, nil
+	}
+
+	out := *img
+
+	for _, t := range i.transformers {
+		var err error
+
+		out, err = t.Transform(ctx, out)
+		if err != nil {
+			return nil, fmt.Errorf("%w", err)
+		}
+	}
+
+	return &out, nil
+}
+
+// BatchResult is the outcome of uploading a single image as part of a batch.
+type BatchResult struct {
+	Image    *Image
+	Response Response
+	Err      error
+}
+
+// BatchOption configures the behaviour of UploadBatch.
+type BatchOption func(*batchConfig)
+
+type batchConfig struct {
+	concurrency int
+	failFast    bool
+	ttl         uint64
+	ttlSet      bool
+}
+
+// WithConcurrency sets how many images are uploaded in parallel. Defaults to 4.
+func WithConcurrency(n int) BatchOption {
+	return func(c *batchConfig) {
+		if n > 0 {
+			c.concurrency = n
+		}
+	}
+}
+
+// WithFailFast cancels the remaining uploads as soon as one image fails.
+func WithFailFast(failFast bool) BatchOption {
+	return func(c *batchConfig) {
+		c.failFast = failFast
+	}
+}
+
+// WithPerImageTTL overrides the expiration applied to every image in the batch.
+func WithPerImageTTL(ttl uint64) BatchOption {
+	return func(c *batchConfig) {
+		c.ttl = ttl
+		c.ttlSet = true
+	}
+}
+
+// UploadBatch uploads several images through a bounded worker pool, returning one
+// BatchResult per input image in the same order. A failed image is reported in its
+// own result and does not prevent the rest of the batch from completing, unless
+// WithFailFast is set.
+func (i *Client) UploadBatch(ctx context.Context, images []*Image, opts ...BatchOption) ([]BatchResult, error) {
+	cfg := batchConfig{concurrency: 4}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	concurrency := cfg.concurrency
+	if concurrency > len(images) {
+		concurrency = len(images)
+	}
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make([]BatchResult, len(images))
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+
+	for n := 0; n < concurrency; n++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for idx := range jobs {
+				img := images[idx]
+
+				if cfg.ttlSet {
+					img.ttl = cfg.ttl
+				}
+
+				resp, err := i.Upload(ctx, img)
+				results[idx] = BatchResult{Image: img, Response: resp, Err: err}
+
+				if err != nil && cfg.failFast {
+					cancel()
+				}
+			}
+		}()
+	}
+
+dispatch:
+	for idx := range images {
+		select {
+		case jobs <- idx:
+		case <-ctx.Done():
+			break dispatch
+		}
+	}
+	close(jobs)
+
+	wg.Wait()
+
+	for idx, img := range images {
+		if results[idx].Image == nil {
+			results[idx] = BatchResult{Image: img, Err: ctx.Err()}
+		}
+	}
+
+	return results, nil
 }
 
 func (i *Client) prepareRequest(ctx context.Context, img *Image) (*http.Request, error) {
@@ -159,10 +540,21 @@ func (i *Client) prepareRequest(ctx context.Context, img *Image) (*http.Request,
 				return
 			}
 
-			if _, err = io.Copy(part, bytes.NewReader(img.file)); err != nil {
+			total := int64(len(img.file))
+
+			reader := io.Reader(bytes.NewReader(img.file))
+			if img.progress != nil {
+				reader = &progressReader{r: reader, total: total, onProgress: img.progress}
+			}
+
+			if _, err = io.Copy(part, reader); err != nil {
 				return
 			}
 
+			if img.progress != nil {
+				img.progress(total, total)
+			}
+
 			return
 		}
 
@@ -175,21 +567,45 @@ func (i *Client) prepareRequest(ctx context.Context, img *Image) (*http.Request,
 		if err != nil {
 			return
 		}
+
+		if img.progress != nil {
+			encoded := int64(len(img.source))
+			img.progress(encoded, encoded)
+		}
 	}()
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, pipeReader)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, i.endpoint, pipeReader)
 	if err != nil {
 		return nil, fmt.Errorf("%w", err)
 	}
 
 	req.Header.Add("Content-Type", mpWriter.FormDataContentType())
-	req.Header.Add("Host", host)
-	req.Header.Add("Origin", origin)
-	req.Header.Add("Referer", referer)
+	req.Header.Add("Host", i.host)
+	req.Header.Add("Origin", i.origin)
+	req.Header.Add("Referer", i.referer)
 
 	return req, nil
 }
 
+// progressReader wraps an io.Reader and reports cumulative bytes read through
+// onProgress as the wrapped reader is consumed.
+type progressReader struct {
+	r          io.Reader
+	total      int64
+	read       int64
+	onProgress ProgressFunc
+}
+
+func (p *progressReader) Read(buf []byte) (int, error) {
+	n, err := p.r.Read(buf)
+	if n > 0 {
+		p.read += int64(n)
+		p.onProgress(p.read, p.total)
+	}
+
+	return n, err
+}
+
 func (i *Client) respParse(resp *http.Response) (Response, error) {
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
@@ -212,3 +628,37 @@ func (i *Client) respParse(resp *http.Response) (Response, error) {
 
 	return res, nil
 }
+
+// Delete removes a previously uploaded image given the delete_url returned for it
+// in Data.DeleteURL.
+func (i *Client) Delete(ctx context.Context, deleteURL string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, deleteURL, nil)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	req.Header.Add("Host", i.host)
+	req.Header.Add("Origin", i.origin)
+	req.Header.Add("Referer", i.referer)
+
+	resp, err := i.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return Error{
+			StatusCode: resp.StatusCode,
+			StatusText: http.StatusText(resp.StatusCode),
+		}
+	}
+
+	return nil
+}
+
+// DeleteResponse is a convenience wrapper around Delete for the Response returned
+// by Upload/UploadBatch.
+func (i *Client) DeleteResponse(ctx context.Context, resp Response) error {
+	return i.Delete(ctx, resp.Data.DeleteURL)
+}
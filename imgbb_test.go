@@ -1,10 +1,14 @@
 package imgbb
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -22,8 +26,8 @@ func Test_Upload_Success(t *testing.T) {
 			"width": 1,
 			"height": 1,
 			"size": 42,
-			"time": "1552042565",
-			"expiration":"0",
+			"time": 1552042565,
+			"expiration": 0,
 			"image": {
 				"filename": "c1f64245afb2.gif",
 				"name": "c1f64245afb2",
@@ -51,9 +55,7 @@ func Test_Upload_Success(t *testing.T) {
 		"status": 200
 	}`
 
-	img := NewImage("name", "", testImg)
-
-	ts := httptest.NewTLSServer(
+	ts := httptest.NewServer(
 		http.HandlerFunc(
 			func(w http.ResponseWriter, r *http.Request) {
 				w.Header().Set("Content-Type", "application/json")
@@ -64,48 +66,51 @@ func Test_Upload_Success(t *testing.T) {
 	)
 	defer ts.Close()
 
-	apiClient := New(*ts.Client(), "secret-key", WithEndpoint(ts.URL))
+	apiClient := NewClient(ts.Client(), "secret-key", WithEndpoint(ts.URL, ts.URL, ts.URL, ts.URL))
+
+	img, err := NewImageFromFile("name", 0, testImg)
+	assert.NoError(t, err)
 
-	expect := &ImgBBResponse{
+	expect := Response{
 		Data: Data{
 			ID:         "2ndCYJK",
 			Title:      "c1f64245afb2",
-			UrlViewer:  "https://ibb.co/2ndCYJK",
-			Url:        "https://i.ibb.co/w04Prt6/c1f64245afb2.gif",
-			DisplayUrl: "https://i.ibb.co/98W13PY/c1f64245afb2.gif",
+			URLViewer:  "https://ibb.co/2ndCYJK",
+			URL:        "https://i.ibb.co/w04Prt6/c1f64245afb2.gif",
+			DisplayURL: "https://i.ibb.co/98W13PY/c1f64245afb2.gif",
 			Width:      1,
 			Height:     1,
 			Size:       42,
-			Time:       "1552042565",
-			Expiration: "0",
+			Time:       1552042565,
+			TTL:        0,
 			Image: Info{
 				Filename:  "c1f64245afb2.gif",
 				Name:      "c1f64245afb2",
 				Mime:      "image/gif",
 				Extension: "gif",
-				Url:       "https://i.ibb.co/w04Prt6/c1f64245afb2.gif",
+				URL:       "https://i.ibb.co/w04Prt6/c1f64245afb2.gif",
 			},
 			Thumb: Info{
 				Filename:  "c1f64245afb2.gif",
 				Name:      "c1f64245afb2",
 				Mime:      "image/gif",
 				Extension: "gif",
-				Url:       "https://i.ibb.co/2ndCYJK/c1f64245afb2.gif",
+				URL:       "https://i.ibb.co/2ndCYJK/c1f64245afb2.gif",
 			},
 			Medium: Info{
 				Filename:  "c1f64245afb2.gif",
 				Name:      "c1f64245afb2",
 				Mime:      "image/gif",
 				Extension: "gif",
-				Url:       "https://i.ibb.co/98W13PY/c1f64245afb2.gif",
+				URL:       "https://i.ibb.co/98W13PY/c1f64245afb2.gif",
 			},
-			DeleteUrl: "https://ibb.co/2ndCYJK/670a7e48ddcb85ac340c717a41047e5c",
+			DeleteURL: "https://ibb.co/2ndCYJK/670a7e48ddcb85ac340c717a41047e5c",
 		},
 		Success:    true,
 		StatusCode: http.StatusOK,
 	}
 
-	actual, err := apiClient.Upload(img)
+	actual, err := apiClient.Upload(context.Background(), img)
 
 	assert.NoError(t, err)
 	assert.Equal(t, expect, actual)
@@ -122,9 +127,7 @@ func Test_Upload_ImgBBError(t *testing.T) {
 		"status_txt": "internal error"
 	}`
 
-	img := NewImage("name", "", testImg)
-
-	ts := httptest.NewTLSServer(
+	ts := httptest.NewServer(
 		http.HandlerFunc(
 			func(w http.ResponseWriter, r *http.Request) {
 				w.Header().Set("Content-Type", "application/json")
@@ -136,19 +139,22 @@ func Test_Upload_ImgBBError(t *testing.T) {
 	)
 	defer ts.Close()
 
-	apiClient := New(*ts.Client(), "secret-key", WithEndpoint(ts.URL))
+	apiClient := NewClient(ts.Client(), "secret-key", WithEndpoint(ts.URL, ts.URL, ts.URL, ts.URL))
+
+	img, err := NewImageFromFile("name", 0, testImg)
+	assert.NoError(t, err)
 
-	expect := ImgBBError{
+	expect := Error{
 		StatusCode: http.StatusInternalServerError,
 		StatusText: "internal error",
-		Err: ErrInfo{
+		Info: ErrorInfo{
 			Code:    999,
 			Message: "error message",
 			Context: "error context",
 		},
 	}
 
-	_, err := apiClient.Upload(img)
+	_, err = apiClient.Upload(context.Background(), img)
 
 	assert.Equal(t, expect, err)
 }
@@ -156,12 +162,35 @@ func Test_Upload_ImgBBError(t *testing.T) {
 func Test_Upload_ClientInternalServerError(t *testing.T) {
 	resp := `bad response format`
 
-	img := NewImage("name", "", testImg)
+	ts := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+
+				fmt.Fprintln(w, resp)
+			},
+		),
+	)
+	defer ts.Close()
+
+	apiClient := NewClient(ts.Client(), "secret-key", WithEndpoint(ts.URL, ts.URL, ts.URL, ts.URL))
+
+	img, err := NewImageFromFile("name", 0, testImg)
+	assert.NoError(t, err)
+
+	_, err = apiClient.Upload(context.Background(), img)
+
+	assert.Error(t, err)
+}
 
-	ts := httptest.NewTLSServer(
+func Test_Upload_ErrorUnmarshalFail(t *testing.T) {
+	resp := `bad error format`
+
+	ts := httptest.NewServer(
 		http.HandlerFunc(
 			func(w http.ResponseWriter, r *http.Request) {
 				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusInternalServerError)
 
 				fmt.Fprintln(w, resp)
 			},
@@ -169,70 +198,287 @@ func Test_Upload_ClientInternalServerError(t *testing.T) {
 	)
 	defer ts.Close()
 
-	apiClient := New(*ts.Client(), "secret-key", WithEndpoint(ts.URL))
+	apiClient := NewClient(ts.Client(), "secret-key", WithEndpoint(ts.URL, ts.URL, ts.URL, ts.URL))
 
-	_, err := apiClient.Upload(img)
+	img, err := NewImageFromFile("name", 0, testImg)
+	assert.NoError(t, err)
 
-	assert.ErrorIs(t, err, ImgBBError{
-		StatusCode: http.StatusInternalServerError,
-		StatusText: http.StatusText(http.StatusInternalServerError),
-	})
+	_, err = apiClient.Upload(context.Background(), img)
+
+	assert.Error(t, err)
 }
 
-func Test_Upload_EmptyImage(t *testing.T) {
-	img := NewImage("name", "", []byte{})
+func Test_UploadBatch_PartialFailure(t *testing.T) {
+	ts := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				if err := r.ParseMultipartForm(1 << 20); err != nil {
+					w.WriteHeader(http.StatusBadRequest)
+
+					return
+				}
 
-	apiClient := New(http.Client{}, "secret-key")
+				if r.FormValue("name") == "bad" {
+					w.WriteHeader(http.StatusBadRequest)
+					fmt.Fprintln(w, `{"status_code":400,"status_txt":"bad request","error":{"message":"nope","code":1,"context":""}}`)
 
-	_, err := apiClient.Upload(img)
+					return
+				}
 
-	assert.ErrorIs(t, err, ImgBBError{
-		StatusCode: http.StatusBadRequest,
-		StatusText: http.StatusText(http.StatusBadRequest),
-	})
+				w.Header().Set("Content-Type", "application/json")
+				fmt.Fprintln(w, `{"data":{"id":"abc"},"success":true,"status":200}`)
+			},
+		),
+	)
+	defer ts.Close()
+
+	client := NewClient(ts.Client(), "secret-key", WithEndpoint(ts.URL, ts.URL, ts.URL, ts.URL))
+
+	good, err := NewImage("good", 0, "c291cmNl")
+	assert.NoError(t, err)
+
+	bad, err := NewImage("bad", 0, "c291cmNl")
+	assert.NoError(t, err)
+
+	results, err := client.UploadBatch(context.Background(), []*Image{good, bad}, WithConcurrency(2))
+	assert.NoError(t, err)
+	assert.Len(t, results, 2)
+
+	assert.NoError(t, results[0].Err)
+	assert.Equal(t, "abc", results[0].Response.Data.ID)
+
+	assert.Error(t, results[1].Err)
 }
 
-func Test_Upload_OversizeImage(t *testing.T) {
-	img := &Image{
-		name:       "name",
-		size:       len(testImg) * 10000000,
-		expiration: "",
-		file:       testImg,
-	}
+func Test_Upload_Progress(t *testing.T) {
+	ts := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				fmt.Fprintln(w, `{"data":{"id":"abc"},"success":true,"status":200}`)
+			},
+		),
+	)
+	defer ts.Close()
 
-	apiClient := New(http.Client{}, "secret-key")
+	client := NewClient(ts.Client(), "secret-key", WithEndpoint(ts.URL, ts.URL, ts.URL, ts.URL))
 
-	_, err := apiClient.Upload(img)
+	var reports [][2]int64
 
-	assert.ErrorIs(t, err, ImgBBError{
-		StatusCode: http.StatusBadRequest,
-		StatusText: http.StatusText(http.StatusBadRequest),
-	})
+	img, err := NewImageFromFile("name", 0, testImg, WithProgress(func(written, total int64) {
+		reports = append(reports, [2]int64{written, total})
+	}))
+	assert.NoError(t, err)
+
+	_, err = client.Upload(context.Background(), img)
+	assert.NoError(t, err)
+
+	assert.NotEmpty(t, reports)
+
+	last := reports[len(reports)-1]
+	assert.Equal(t, last[0], last[1])
+	assert.Equal(t, int64(len(testImg)), last[1])
 }
 
-func Test_Upload_ErrorUnmarshalFail(t *testing.T) {
-	resp := `bad error format`
+type stubTransformer struct {
+	fn func(in Image) (Image, error)
+}
+
+func (s stubTransformer) Transform(_ context.Context, in Image) (Image, error) {
+	return s.fn(in)
+}
 
-	img := NewImage("name", "", testImg)
+func Test_Upload_AppliesTransformers(t *testing.T) {
+	var gotFile []byte
 
-	ts := httptest.NewTLSServer(
+	ts := httptest.NewServer(
 		http.HandlerFunc(
 			func(w http.ResponseWriter, r *http.Request) {
+				if err := r.ParseMultipartForm(1 << 20); err != nil {
+					w.WriteHeader(http.StatusBadRequest)
+
+					return
+				}
+
+				file, _, err := r.FormFile("image")
+				assert.NoError(t, err)
+				defer file.Close()
+
+				gotFile, err = io.ReadAll(file)
+				assert.NoError(t, err)
+
 				w.Header().Set("Content-Type", "application/json")
-				w.WriteHeader(http.StatusInternalServerError)
+				fmt.Fprintln(w, `{"data":{"id":"abc"},"success":true,"status":200}`)
+			},
+		),
+	)
+	defer ts.Close()
 
-				fmt.Fprintln(w, resp)
+	transformed := []byte{1, 2, 3}
+
+	transformer := stubTransformer{fn: func(in Image) (Image, error) {
+		out, err := NewImageFromFile(in.Name(), in.TTL(), transformed)
+		assert.NoError(t, err)
+
+		return *out, nil
+	}}
+
+	client := NewClient(ts.Client(), "secret-key", WithTransformers(transformer), WithEndpoint(ts.URL, ts.URL, ts.URL, ts.URL))
+
+	img, err := NewImageFromFile("name", 0, testImg)
+	assert.NoError(t, err)
+
+	_, err = client.Upload(context.Background(), img)
+	assert.NoError(t, err)
+
+	assert.Equal(t, transformed, gotFile)
+}
+
+func Test_Upload_RetriesOnServerError(t *testing.T) {
+	var attempts int32
+
+	ts := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				if atomic.AddInt32(&attempts, 1) < 3 {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					fmt.Fprintln(w, `{"status_code":503,"status_txt":"unavailable","error":{"message":"retry","code":1,"context":""}}`)
+
+					return
+				}
+
+				w.Header().Set("Content-Type", "application/json")
+				fmt.Fprintln(w, `{"data":{"id":"abc"},"success":true,"status":200}`)
 			},
 		),
 	)
 	defer ts.Close()
 
-	apiClient := New(*ts.Client(), "secret-key", WithEndpoint(ts.URL))
+	client := NewClient(
+		ts.Client(),
+		"secret-key",
+		WithEndpoint(ts.URL, ts.URL, ts.URL, ts.URL),
+		WithRetry(RetryPolicy{MaxAttempts: 5, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond}),
+	)
+
+	img, err := NewImageFromFile("name", 0, testImg)
+	assert.NoError(t, err)
 
-	_, err := apiClient.Upload(img)
+	resp, err := client.Upload(context.Background(), img)
+	assert.NoError(t, err)
+	assert.Equal(t, "abc", resp.Data.ID)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&attempts))
+}
 
-	assert.ErrorIs(t, err, ImgBBError{
-		StatusCode: http.StatusInternalServerError,
-		StatusText: http.StatusText(http.StatusInternalServerError),
+func Test_Upload_RetryGivesUpAfterMaxAttempts(t *testing.T) {
+	ts := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				fmt.Fprintln(w, `{"status_code":503,"status_txt":"unavailable","error":{"message":"retry","code":1,"context":""}}`)
+			},
+		),
+	)
+	defer ts.Close()
+
+	client := NewClient(
+		ts.Client(),
+		"secret-key",
+		WithEndpoint(ts.URL, ts.URL, ts.URL, ts.URL),
+		WithRetry(RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond}),
+	)
+
+	img, err := NewImageFromFile("name", 0, testImg)
+	assert.NoError(t, err)
+
+	_, err = client.Upload(context.Background(), img)
+	assert.Error(t, err)
+}
+
+func Test_Upload_DoesNotRetryClientError(t *testing.T) {
+	var attempts int32
+
+	ts := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&attempts, 1)
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprintln(w, `{"status_code":400,"status_txt":"bad request","error":{"message":"bad key","code":1,"context":""}}`)
+			},
+		),
+	)
+	defer ts.Close()
+
+	client := NewClient(
+		ts.Client(),
+		"secret-key",
+		WithEndpoint(ts.URL, ts.URL, ts.URL, ts.URL),
+		WithRetry(RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond}),
+	)
+
+	img, err := NewImageFromFile("name", 0, testImg)
+	assert.NoError(t, err)
+
+	_, err = client.Upload(context.Background(), img)
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&attempts))
+}
+
+func Test_Delete_Success(t *testing.T) {
+	ts := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodGet, r.Method)
+				assert.Equal(t, "/2ndCYJK/670a7e48ddcb85ac340c717a41047e5c", r.URL.Path)
+
+				w.WriteHeader(http.StatusOK)
+			},
+		),
+	)
+	defer ts.Close()
+
+	client := NewClient(ts.Client(), "secret-key", WithEndpoint(ts.URL, ts.URL, ts.URL, ts.URL))
+
+	err := client.Delete(context.Background(), ts.URL+"/2ndCYJK/670a7e48ddcb85ac340c717a41047e5c")
+	assert.NoError(t, err)
+}
+
+func Test_Delete_Failure(t *testing.T) {
+	ts := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+			},
+		),
+	)
+	defer ts.Close()
+
+	client := NewClient(ts.Client(), "secret-key", WithEndpoint(ts.URL, ts.URL, ts.URL, ts.URL))
+
+	err := client.Delete(context.Background(), ts.URL+"/unknown")
+	assert.ErrorIs(t, err, Error{
+		StatusCode: http.StatusNotFound,
+		StatusText: http.StatusText(http.StatusNotFound),
 	})
 }
+
+func Test_DeleteResponse(t *testing.T) {
+	var gotPath string
+
+	ts := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				gotPath = r.URL.Path
+				w.WriteHeader(http.StatusOK)
+			},
+		),
+	)
+	defer ts.Close()
+
+	client := NewClient(ts.Client(), "secret-key", WithEndpoint(ts.URL, ts.URL, ts.URL, ts.URL))
+
+	resp := Response{Data: Data{DeleteURL: ts.URL + "/2ndCYJK/deadbeef"}}
+
+	assert.NoError(t, client.DeleteResponse(context.Background(), resp))
+	assert.Equal(t, "/2ndCYJK/deadbeef", gotPath)
+}
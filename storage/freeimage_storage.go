@@ -0,0 +1,43 @@
+package storage
+
+import (
+	"context"
+	"net/http"
+
+	imgbb "github.com/JohnNON/ImgBB"
+)
+
+const (
+	freeimageEndpoint = "https://freeimage.host/api/1/upload"
+	freeimageHost     = "freeimage.host"
+	freeimageOrigin   = "https://freeimage.host"
+	freeimageReferer  = "https://freeimage.host/"
+)
+
+// freeimageStorage adapts an imgbb.Client targeting Freeimage.host, which is also
+// Chevereto-based and accepts the same multipart form contract as ImgBB.
+type freeimageStorage struct {
+	client *imgbb.Client
+}
+
+// NewFreeimageStorage wraps an imgbb.Client targeting freeimage.host as a Storage
+// backend. opts is passed through to imgbb.NewClient, applied after the
+// freeimage.host endpoint so callers can still override it (e.g. in tests).
+func NewFreeimageStorage(httpClient *http.Client, apiKey string, opts ...imgbb.ClientOption) Storage {
+	allOpts := append(
+		[]imgbb.ClientOption{imgbb.WithEndpoint(freeimageEndpoint, freeimageHost, freeimageOrigin, freeimageReferer)},
+		opts...,
+	)
+
+	return &freeimageStorage{client: imgbb.NewClient(httpClient, apiKey, allOpts...)}
+}
+
+// Upload implements Storage.
+func (s *freeimageStorage) Upload(ctx context.Context, img *imgbb.Image) (imgbb.Response, error) {
+	return s.client.Upload(ctx, img)
+}
+
+// Delete implements Storage. Freeimage.host has no documented delete API.
+func (s *freeimageStorage) Delete(_ context.Context, _ string) error {
+	return ErrDeleteUnsupported
+}
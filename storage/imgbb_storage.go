@@ -0,0 +1,33 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	imgbb "github.com/JohnNON/ImgBB"
+)
+
+// ErrDeleteUnsupported is returned by backends that have no programmatic delete.
+var ErrDeleteUnsupported = errors.New("storage: delete not supported by this backend")
+
+// imgBBStorage adapts an imgbb.Client to the Storage interface.
+type imgBBStorage struct {
+	client *imgbb.Client
+}
+
+// NewImgBBStorage wraps an imgbb.Client targeting api.imgbb.com as a Storage
+// backend. opts is passed through to imgbb.NewClient.
+func NewImgBBStorage(httpClient *http.Client, apiKey string, opts ...imgbb.ClientOption) Storage {
+	return &imgBBStorage{client: imgbb.NewClient(httpClient, apiKey, opts...)}
+}
+
+// Upload implements Storage.
+func (s *imgBBStorage) Upload(ctx context.Context, img *imgbb.Image) (imgbb.Response, error) {
+	return s.client.Upload(ctx, img)
+}
+
+// Delete implements Storage.
+func (s *imgBBStorage) Delete(ctx context.Context, deleteURL string) error {
+	return s.client.Delete(ctx, deleteURL)
+}
@@ -0,0 +1,58 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	imgbb "github.com/JohnNON/ImgBB"
+)
+
+// mockStorage is an in-memory Storage backend for tests. It makes no network calls
+// and fully supports Delete.
+type mockStorage struct {
+	mu     sync.Mutex
+	images map[string]*imgbb.Image
+	nextID int64
+}
+
+// NewMockStorage returns an in-memory Storage backend suitable for tests.
+func NewMockStorage() Storage {
+	return &mockStorage{images: make(map[string]*imgbb.Image)}
+}
+
+// Upload implements Storage. The returned Data.DeleteURL is the handle Delete
+// expects, same as the real hosted backends.
+func (s *mockStorage) Upload(_ context.Context, img *imgbb.Image) (imgbb.Response, error) {
+	id := fmt.Sprintf("mock%d", atomic.AddInt64(&s.nextID, 1))
+	deleteURL := "mock://delete/" + id
+
+	s.mu.Lock()
+	s.images[deleteURL] = img
+	s.mu.Unlock()
+
+	return imgbb.Response{
+		Success:    true,
+		StatusCode: 200,
+		Data: imgbb.Data{
+			ID:        id,
+			Size:      img.Size(),
+			DeleteURL: deleteURL,
+		},
+	}, nil
+}
+
+// Delete implements Storage.
+func (s *mockStorage) Delete(_ context.Context, deleteURL string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.images[deleteURL]; !ok {
+		return fmt.Errorf("storage: mock image %q not found", deleteURL)
+	}
+
+	delete(s.images, deleteURL)
+
+	return nil
+}
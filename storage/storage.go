@@ -0,0 +1,65 @@
+// Package storage defines a provider-agnostic image hosting abstraction on top of
+// imgbb.Client, so callers can depend on an interface instead of one specific host.
+package storage
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	imgbb "github.com/JohnNON/ImgBB"
+)
+
+// Storage is an image hosting backend: upload an Image, and delete a previously
+// uploaded one.
+type Storage interface {
+	Upload(ctx context.Context, img *imgbb.Image) (imgbb.Response, error)
+	// Delete removes a previously uploaded image using the opaque delete handle
+	// returned as Response.Data.DeleteURL by Upload, not the image id. Backends
+	// with no programmatic delete return ErrDeleteUnsupported.
+	Delete(ctx context.Context, deleteURL string) error
+}
+
+// Backend selects which Storage implementation New constructs.
+type Backend string
+
+const (
+	// BackendImgBB targets api.imgbb.com. It is the default when Backend is empty.
+	BackendImgBB Backend = "imgbb"
+	// BackendFreeimage targets freeimage.host, a Chevereto-based sibling of ImgBB
+	// that accepts the same multipart form contract.
+	BackendFreeimage Backend = "freeimage"
+	// BackendMock is an in-memory backend for tests, with no network calls.
+	BackendMock Backend = "mock"
+)
+
+// Config configures the backend constructed by New.
+type Config struct {
+	Backend    Backend
+	HTTPClient *http.Client
+	APIKey     string
+	// ClientOptions is passed through to imgbb.NewClient for the ImgBB and
+	// Freeimage backends, so callers can still reach imgbb.WithTransformers,
+	// imgbb.WithRetry, etc. through the Storage abstraction. Ignored by
+	// BackendMock.
+	ClientOptions []imgbb.ClientOption
+}
+
+// New constructs the Storage backend selected by cfg.Backend.
+func New(cfg Config) (Storage, error) {
+	client := cfg.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	switch cfg.Backend {
+	case BackendImgBB, "":
+		return NewImgBBStorage(client, cfg.APIKey, cfg.ClientOptions...), nil
+	case BackendFreeimage:
+		return NewFreeimageStorage(client, cfg.APIKey, cfg.ClientOptions...), nil
+	case BackendMock:
+		return NewMockStorage(), nil
+	default:
+		return nil, fmt.Errorf("storage: unknown backend %q", cfg.Backend)
+	}
+}
@@ -0,0 +1,72 @@
+package storage
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	imgbb "github.com/JohnNON/ImgBB"
+)
+
+func Test_MockStorage_UploadAndDelete(t *testing.T) {
+	s, err := New(Config{Backend: BackendMock})
+	assert.NoError(t, err)
+
+	img, err := imgbb.NewImageFromFile("name", 0, []byte{1, 2, 3})
+	assert.NoError(t, err)
+
+	resp, err := s.Upload(context.Background(), img)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, resp.Data.DeleteURL)
+
+	assert.NoError(t, s.Delete(context.Background(), resp.Data.DeleteURL))
+	assert.Error(t, s.Delete(context.Background(), resp.Data.DeleteURL))
+}
+
+func Test_ImgBBStorage_UploadAndDelete(t *testing.T) {
+	var deletedPath string
+
+	var ts *httptest.Server
+
+	ts = httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodGet {
+					deletedPath = r.URL.Path
+					w.WriteHeader(http.StatusOK)
+
+					return
+				}
+
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{"data":{"id":"abc","delete_url":"` + ts.URL + `/abc/deadbeef"},"success":true,"status":200}`))
+			},
+		),
+	)
+	defer ts.Close()
+
+	s, err := New(Config{
+		Backend:       BackendImgBB,
+		HTTPClient:    ts.Client(),
+		ClientOptions: []imgbb.ClientOption{imgbb.WithEndpoint(ts.URL, ts.URL, ts.URL, ts.URL)},
+	})
+	assert.NoError(t, err)
+
+	img, err := imgbb.NewImageFromFile("name", 0, []byte{1, 2, 3})
+	assert.NoError(t, err)
+
+	resp, err := s.Upload(context.Background(), img)
+	assert.NoError(t, err)
+	assert.Equal(t, ts.URL+"/abc/deadbeef", resp.Data.DeleteURL)
+
+	assert.NoError(t, s.Delete(context.Background(), resp.Data.DeleteURL))
+	assert.Equal(t, "/abc/deadbeef", deletedPath)
+}
+
+func Test_New_UnknownBackend(t *testing.T) {
+	_, err := New(Config{Backend: "bogus"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,59 @@
+package transform
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/jpeg"
+
+	imgbb "github.com/JohnNON/ImgBB"
+)
+
+// ImgBBMaxBytes is ImgBB's documented upload size limit (32 MiB).
+const ImgBBMaxBytes = 32 << 20
+
+// ShrinkUnder repeatedly re-encodes an image as JPEG at decreasing quality until the
+// result is at or under MaxBytes, or MinQuality is reached. MinQuality defaults to 10
+// when left at 0.
+type ShrinkUnder struct {
+	MaxBytes   int
+	MinQuality int
+}
+
+// Transform implements imgbb.Transformer.
+func (t ShrinkUnder) Transform(ctx context.Context, in imgbb.Image) (imgbb.Image, error) {
+	if in.File() == nil || len(in.File()) <= t.MaxBytes {
+		return in, nil
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(in.File()))
+	if err != nil {
+		return imgbb.Image{}, fmt.Errorf("transform: decode image: %w", err)
+	}
+
+	minQuality := t.MinQuality
+	if minQuality <= 0 {
+		minQuality = 10
+	}
+
+	var buf bytes.Buffer
+
+	for quality := 90; quality >= minQuality; quality -= 10 {
+		if err := ctx.Err(); err != nil {
+			return imgbb.Image{}, err
+		}
+
+		buf.Reset()
+
+		if err := jpeg.Encode(&buf, src, &jpeg.Options{Quality: quality}); err != nil {
+			return imgbb.Image{}, fmt.Errorf("transform: encode image: %w", err)
+		}
+
+		if buf.Len() <= t.MaxBytes {
+			break
+		}
+	}
+
+	return rebuild(in, buf.Bytes())
+}
@@ -0,0 +1,48 @@
+package transform
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+
+	imgbb "github.com/JohnNON/ImgBB"
+)
+
+// Reencoder re-encodes an image to a target format at a given quality. Supported
+// output formats are "jpeg" and "png"; Quality is only used when Format is "jpeg".
+// WebP input is decoded (via the registered golang.org/x/image/webp decoder), but
+// there is no pure-Go WebP encoder, so WebP is not a supported output Format.
+type Reencoder struct {
+	Format  string
+	Quality int
+}
+
+// Transform implements imgbb.Transformer.
+func (t Reencoder) Transform(_ context.Context, in imgbb.Image) (imgbb.Image, error) {
+	if in.File() == nil {
+		return in, nil
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(in.File()))
+	if err != nil {
+		return imgbb.Image{}, fmt.Errorf("transform: decode image: %w", err)
+	}
+
+	var buf bytes.Buffer
+
+	switch t.Format {
+	case "png":
+		err = png.Encode(&buf, src)
+	default:
+		err = jpeg.Encode(&buf, src, &jpeg.Options{Quality: t.Quality})
+	}
+
+	if err != nil {
+		return imgbb.Image{}, fmt.Errorf("transform: encode image: %w", err)
+	}
+
+	return rebuild(in, buf.Bytes())
+}
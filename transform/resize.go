@@ -0,0 +1,66 @@
+// Package transform provides optional imgbb.Transformer implementations — resizing,
+// re-encoding, and size-constrained compression — applied to images before upload.
+package transform
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+
+	xdraw "golang.org/x/image/draw"
+
+	imgbb "github.com/JohnNON/ImgBB"
+)
+
+// MaxDimension resizes an image so that neither its width nor its height exceeds
+// Max, preserving aspect ratio using a Catmull-Rom kernel. Images already within
+// bounds are returned unchanged.
+type MaxDimension struct {
+	Max int
+}
+
+// Transform implements imgbb.Transformer.
+func (t MaxDimension) Transform(_ context.Context, in imgbb.Image) (imgbb.Image, error) {
+	if in.File() == nil {
+		return in, nil
+	}
+
+	src, format, err := image.Decode(bytes.NewReader(in.File()))
+	if err != nil {
+		return imgbb.Image{}, fmt.Errorf("transform: decode image: %w", err)
+	}
+
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	if width <= t.Max && height <= t.Max {
+		return in, nil
+	}
+
+	scale := float64(t.Max) / float64(width)
+	if hScale := float64(t.Max) / float64(height); hScale < scale {
+		scale = hScale
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, int(float64(width)*scale), int(float64(height)*scale)))
+	xdraw.CatmullRom.Scale(dst, dst.Bounds(), src, bounds, draw.Over, nil)
+
+	var buf bytes.Buffer
+	if err := encode(&buf, dst, format); err != nil {
+		return imgbb.Image{}, fmt.Errorf("transform: encode image: %w", err)
+	}
+
+	return rebuild(in, buf.Bytes())
+}
+
+func encode(buf *bytes.Buffer, img image.Image, format string) error {
+	if format == "png" {
+		return png.Encode(buf, img)
+	}
+
+	return jpeg.Encode(buf, img, &jpeg.Options{Quality: jpeg.DefaultQuality})
+}
@@ -0,0 +1,56 @@
+package transform
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	imgbb "github.com/JohnNON/ImgBB"
+)
+
+func encodedPNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for x := 0; x < width; x++ {
+		for y := 0; y < height; y++ {
+			img.Set(x, y, color.White)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("encode test png: %v", err)
+	}
+
+	return buf.Bytes()
+}
+
+func Test_MaxDimension_NoopWhenWithinBounds(t *testing.T) {
+	in, err := imgbb.NewImageFromFile("name", 0, encodedPNG(t, 10, 10))
+	assert.NoError(t, err)
+
+	out, err := (MaxDimension{Max: 100}).Transform(context.Background(), *in)
+	assert.NoError(t, err)
+	assert.Equal(t, in.File(), out.File())
+}
+
+func Test_MaxDimension_Resizes(t *testing.T) {
+	in, err := imgbb.NewImageFromFile("name", 0, encodedPNG(t, 200, 100))
+	assert.NoError(t, err)
+
+	out, err := (MaxDimension{Max: 50}).Transform(context.Background(), *in)
+	assert.NoError(t, err)
+
+	decoded, _, err := image.Decode(bytes.NewReader(out.File()))
+	assert.NoError(t, err)
+
+	bounds := decoded.Bounds()
+	assert.LessOrEqual(t, bounds.Dx(), 50)
+	assert.LessOrEqual(t, bounds.Dy(), 50)
+}
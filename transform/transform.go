@@ -0,0 +1,27 @@
+package transform
+
+import (
+	"fmt"
+
+	// Registers WebP decoding with image.Decode so transformers can read WebP
+	// input. There is no pure-Go WebP encoder, so output is always jpeg/png.
+	_ "golang.org/x/image/webp"
+
+	imgbb "github.com/JohnNON/ImgBB"
+)
+
+// rebuild constructs the transformed Image, carrying forward any ProgressFunc
+// attached to in so a transformer doesn't silently drop upload progress reporting.
+func rebuild(in imgbb.Image, data []byte) (imgbb.Image, error) {
+	var opts []imgbb.ImageOption
+	if p := in.Progress(); p != nil {
+		opts = append(opts, imgbb.WithProgress(p))
+	}
+
+	out, err := imgbb.NewImageFromFile(in.Name(), in.TTL(), data, opts...)
+	if err != nil {
+		return imgbb.Image{}, fmt.Errorf("transform: rebuild image: %w", err)
+	}
+
+	return *out, nil
+}